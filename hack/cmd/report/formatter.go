@@ -0,0 +1,264 @@
+// Copyright 2024 Registry Operator contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Formatter renders a Report in a particular output format.
+type Formatter interface {
+	Format(r *Report, w io.Writer) error
+}
+
+// formatterFor resolves the --format flag value to a Formatter.
+func formatterFor(name string) (Formatter, error) {
+	switch name {
+	case "", "markdown":
+		return MarkdownFormatter{}, nil
+	case "json":
+		return JSONFormatter{}, nil
+	case "csv":
+		return CSVFormatter{}, nil
+	case "html":
+		return HTMLFormatter{}, nil
+	case "junit":
+		return JUnitFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (want markdown, json, csv, html, junit)", name)
+	}
+}
+
+// MarkdownFormatter renders the report as GitHub-flavored markdown, the
+// tool's original and still-default output.
+type MarkdownFormatter struct{}
+
+func (MarkdownFormatter) Format(r *Report, w io.Writer) error {
+	fmt.Fprintf(w, "## E2E report %s\n", ifElse(r.OK, Passed, Failed))
+	fmt.Fprintf(w, "Started at `%s` took `%s`\n\n", r.Timestamp, r.Time)
+	fmt.Fprintf(w, "![](https://img.shields.io/badge/tests-%d_passed%%2C_%d_failed%%2C_%d_skipped-%s)\n\n",
+		r.Passes, r.Failures, r.Skips, ifElse(r.OK, "green", "red"))
+
+	// Print table headers and separator
+	fmt.Fprintln(w, strings.Join(r.Table.Headers, "|"))
+	fmt.Fprintln(w, strings.Join(r.Table.Separator, "|"))
+
+	// Sort and print table rows by status
+	sort.Slice(r.Table.Rows, func(i, j int) bool {
+		return r.Table.Rows[i][len(r.Table.Rows[i])-1] > r.Table.Rows[j][len(r.Table.Rows[j])-1]
+	})
+	for _, row := range r.Table.Rows {
+		fmt.Fprintln(w, strings.Join(row, "|"))
+	}
+
+	printFailureDetails(w, r)
+	return nil
+}
+
+// printFailureDetails renders a collapsible <details> block per failing case
+// with its failure message and captured stdout/stderr, so a PR reviewer can
+// triage a failure straight from the comment.
+func printFailureDetails(w io.Writer, r *Report) {
+	var failing []ReportCase
+	for _, c := range r.Cases {
+		if c.Status == StatusFailed {
+			failing = append(failing, c)
+		}
+	}
+	if len(failing) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n### Failure details\n")
+	for _, c := range failing {
+		fmt.Fprintf(w, "\n<details>\n<summary>%s / %s</summary>\n\n", c.Suite, c.Case)
+		if c.Failure != nil && *c.Failure != "" {
+			printPre(w, *c.Failure)
+		}
+		if c.SystemOut != "" {
+			fmt.Fprintf(w, "\n<details>\n<summary>stdout</summary>\n\n")
+			printPre(w, c.SystemOut)
+			fmt.Fprintf(w, "\n</details>\n")
+		}
+		if c.SystemErr != "" {
+			fmt.Fprintf(w, "\n<details>\n<summary>stderr</summary>\n\n")
+			printPre(w, c.SystemErr)
+			fmt.Fprintf(w, "\n</details>\n")
+		}
+		fmt.Fprintf(w, "\n</details>\n")
+	}
+}
+
+// printPre renders text as an HTML-escaped <pre> block rather than a
+// markdown code fence, so captured test output containing its own ``` ```
+// sequence (a diff, a chainsaw log echoing markdown) can't prematurely
+// terminate the fence and garble the rest of the report.
+func printPre(w io.Writer, text string) {
+	fmt.Fprintf(w, "<pre>\n%s\n</pre>\n", html.EscapeString(text))
+}
+
+// jsonCase is the stable schema emitted by JSONFormatter for downstream tooling.
+type jsonCase struct {
+	Suite          string  `json:"suite"`
+	Case           string  `json:"case"`
+	TimeSeconds    float64 `json:"time_seconds"`
+	Status         string  `json:"status"`
+	FailureMessage string  `json:"failure_message,omitempty"`
+	SystemOut      string  `json:"system_out,omitempty"`
+	SystemErr      string  `json:"system_err,omitempty"`
+}
+
+// JSONFormatter emits a stable, machine-readable schema.
+type JSONFormatter struct{}
+
+func (JSONFormatter) Format(r *Report, w io.Writer) error {
+	cases := make([]jsonCase, 0, len(r.Cases))
+	for _, c := range r.Cases {
+		seconds, _ := strconv.ParseFloat(c.Time, 64)
+		jc := jsonCase{
+			Suite:       c.Suite,
+			Case:        c.Case,
+			TimeSeconds: seconds,
+			Status:      string(c.Status),
+			SystemOut:   c.SystemOut,
+			SystemErr:   c.SystemErr,
+		}
+		if c.Failure != nil {
+			jc.FailureMessage = *c.Failure
+		}
+		cases = append(cases, jc)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Timestamp string     `json:"timestamp"`
+		Time      string     `json:"time"`
+		Passes    int        `json:"passes"`
+		Failures  int        `json:"failures"`
+		Skipped   int        `json:"skipped"`
+		OK        bool       `json:"ok"`
+		Cases     []jsonCase `json:"cases"`
+	}{
+		Timestamp: r.Timestamp,
+		Time:      r.Time,
+		Passes:    r.Passes,
+		Failures:  r.Failures,
+		Skipped:   r.Skips,
+		OK:        r.OK,
+		Cases:     cases,
+	})
+}
+
+// CSVFormatter emits one row per test case.
+type CSVFormatter struct{}
+
+func (CSVFormatter) Format(r *Report, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	if err := cw.Write([]string{"suite", "case", "time_seconds", "status", "failure_message"}); err != nil {
+		return err
+	}
+	for _, c := range r.Cases {
+		failure := ""
+		if c.Failure != nil {
+			failure = *c.Failure
+		}
+		if err := cw.Write([]string{c.Suite, c.Case, c.Time, string(c.Status), failure}); err != nil {
+			return err
+		}
+	}
+	return cw.Error()
+}
+
+// HTMLFormatter renders a self-contained page with sortable columns.
+type HTMLFormatter struct{}
+
+func (HTMLFormatter) Format(r *Report, w io.Writer) error {
+	fmt.Fprintf(w, "<!doctype html>\n<html><head><meta charset=\"utf-8\"><title>E2E report</title>\n")
+	fmt.Fprintf(w, "<style>table{border-collapse:collapse;width:100%%}th,td{border:1px solid #ccc;padding:4px 8px;text-align:left}th{cursor:pointer;background:#f0f0f0}tr.failed{background:#fee}tr.passed{background:#efe}</style>\n")
+	fmt.Fprintf(w, "<script>\nfunction sortTable(col){\n  var table=document.getElementById('cases');\n  var rows=Array.prototype.slice.call(table.tBodies[0].rows);\n  var asc=table.getAttribute('data-sort-col')!=col||table.getAttribute('data-sort-dir')!=='asc';\n  rows.sort(function(a,b){\n    var x=a.cells[col].innerText, y=b.cells[col].innerText;\n    return asc?x.localeCompare(y,undefined,{numeric:true}):y.localeCompare(x,undefined,{numeric:true});\n  });\n  rows.forEach(function(row){table.tBodies[0].appendChild(row)});\n  table.setAttribute('data-sort-col',col);\n  table.setAttribute('data-sort-dir',asc?'asc':'desc');\n}\n</script>\n</head><body>\n")
+	fmt.Fprintf(w, "<h1>E2E report: %s</h1>\n", ifElse(r.OK, "passed", "failed"))
+	fmt.Fprintf(w, "<p>Started at %s, took %s. %d passed, %d failed.</p>\n",
+		html.EscapeString(r.Timestamp), html.EscapeString(r.Time), r.Passes, r.Failures)
+
+	fmt.Fprintf(w, "<table id=\"cases\"><thead><tr>")
+	for i, header := range []string{"Test Suite", "Test Case", "Time (s)", "Status"} {
+		fmt.Fprintf(w, "<th onclick=\"sortTable(%d)\">%s</th>", i, html.EscapeString(header))
+	}
+	fmt.Fprintf(w, "</tr></thead><tbody>\n")
+	for _, c := range r.Cases {
+		fmt.Fprintf(w, "<tr class=\"%s\"><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+			string(c.Status), html.EscapeString(c.Suite), html.EscapeString(c.Case),
+			html.EscapeString(c.Time), html.EscapeString(string(c.Status)))
+	}
+	fmt.Fprintf(w, "</tbody></table>\n</body></html>\n")
+	return nil
+}
+
+// JUnitFormatter re-emits the report as normalized JUnit XML, grouping cases
+// back under their originating suite.
+type JUnitFormatter struct{}
+
+func (JUnitFormatter) Format(r *Report, w io.Writer) error {
+	suiteOrder := []string{}
+	suiteCases := map[string][]TestCase{}
+	for _, c := range r.Cases {
+		if _, ok := suiteCases[c.Suite]; !ok {
+			suiteOrder = append(suiteOrder, c.Suite)
+		}
+		tc := TestCase{
+			Name:       c.Case,
+			Time:       c.Time,
+			SystemOut:  c.SystemOut,
+			SystemErr:  c.SystemErr,
+			Properties: c.Properties,
+		}
+		switch c.Status {
+		case StatusFailed:
+			var msg string
+			if c.Failure != nil {
+				msg = *c.Failure
+			}
+			tc.Failure = &Failure{Text: msg}
+		case StatusSkipped:
+			tc.Skipped = &Skip{}
+		}
+		suiteCases[c.Suite] = append(suiteCases[c.Suite], tc)
+	}
+
+	out := TestSuites{Time: r.Time, Timestamp: r.Timestamp}
+	for _, name := range suiteOrder {
+		out.TestSuite = append(out.TestSuite, TestSuite{Name: name, TestCase: suiteCases[name]})
+	}
+
+	fmt.Fprint(w, xml.Header)
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	return nil
+}
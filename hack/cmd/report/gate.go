@@ -0,0 +1,109 @@
+// Copyright 2024 Registry Operator contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// GatePolicy configures --gate mode: the report is still rendered normally,
+// but the tool exits non-zero when any configured policy is violated.
+type GatePolicy struct {
+	FailOnAny   bool
+	MaxFailures int
+	MaxDuration time.Duration
+	Baseline    string
+}
+
+// GateResult is the outcome of evaluating a GatePolicy against a Report.
+type GateResult struct {
+	Violations  []string
+	Regressions []ReportCase
+	Fixed       []ReportCase
+}
+
+// evaluateGate checks r against policy, diffing against policy.Baseline (a
+// prior chainsaw-xml report) when set to detect regressions while ignoring
+// pre-existing failures.
+func evaluateGate(r *Report, policy GatePolicy) (GateResult, error) {
+	var result GateResult
+
+	if policy.FailOnAny && r.Failures > 0 {
+		result.Violations = append(result.Violations, fmt.Sprintf("%d test(s) failed", r.Failures))
+	}
+	if policy.MaxFailures >= 0 && r.Failures > policy.MaxFailures {
+		result.Violations = append(result.Violations, fmt.Sprintf("%d failure(s) exceed --max-failures=%d", r.Failures, policy.MaxFailures))
+	}
+	if policy.MaxDuration > 0 {
+		if took, err := time.ParseDuration(r.Time + "s"); err == nil && took > policy.MaxDuration {
+			result.Violations = append(result.Violations, fmt.Sprintf("run took %s, exceeds --max-duration=%s", took, policy.MaxDuration))
+		}
+	}
+
+	if policy.Baseline != "" {
+		baselineSuites, err := parseReport(policy.Baseline, "chainsaw-xml")
+		if err != nil {
+			return result, fmt.Errorf("failed to read baseline report: %w", err)
+		}
+
+		baselineStatus := map[string]CaseStatus{}
+		for _, c := range casesFromSuites(baselineSuites) {
+			baselineStatus[trendKey(c.Suite, c.Case)] = c.Status
+		}
+
+		for _, c := range r.Cases {
+			prior, ok := baselineStatus[trendKey(c.Suite, c.Case)]
+			if !ok {
+				continue
+			}
+			switch {
+			case prior == StatusPassed && c.Status == StatusFailed:
+				result.Regressions = append(result.Regressions, c)
+			case prior == StatusFailed && c.Status == StatusPassed:
+				result.Fixed = append(result.Fixed, c)
+			}
+		}
+
+		if len(result.Regressions) > 0 {
+			result.Violations = append(result.Violations, fmt.Sprintf("%d regression(s) vs baseline", len(result.Regressions)))
+		}
+	}
+
+	return result, nil
+}
+
+// printGateSections renders the "Regressions" and "Fixed" markdown sections
+// summarizing the delta against the gate's baseline.
+func printGateSections(w io.Writer, result GateResult) {
+	fmt.Fprintf(w, "\n### Regressions\n\n")
+	if len(result.Regressions) == 0 {
+		fmt.Fprintln(w, "None")
+	} else {
+		for _, c := range result.Regressions {
+			fmt.Fprintf(w, "- `%s / %s`\n", c.Suite, c.Case)
+		}
+	}
+
+	fmt.Fprintf(w, "\n### Fixed\n\n")
+	if len(result.Fixed) == 0 {
+		fmt.Fprintln(w, "None")
+	} else {
+		for _, c := range result.Fixed {
+			fmt.Fprintf(w, "- `%s / %s`\n", c.Suite, c.Case)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2024 Registry Operator contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	reGoTestRun = regexp.MustCompile(`^=== RUN\s+(\S+)`)
+	reGoTestEnd = regexp.MustCompile(`^\s*--- (PASS|FAIL|SKIP): (\S+) \(([\d.]+)s\)`)
+	reGoTestPkg = regexp.MustCompile(`^(ok|FAIL)\s+(\S+)\s+([\d.]+)s`)
+)
+
+// parseGoTestText converts raw `go test -v` output into the same
+// TestSuites/TestCase structures the chainsaw-xml path consumes, similar to
+// how go2xunit reconstructs suites from === RUN / --- PASS|FAIL|SKIP / ok|FAIL
+// package summary lines.
+func parseGoTestText(r io.Reader) (TestSuites, error) {
+	var suites TestSuites
+
+	var cases []TestCase
+	outputs := map[string]*strings.Builder{}
+	current := ""
+
+	flush := func(pkg string) {
+		if len(cases) == 0 {
+			return
+		}
+		suites.TestSuite = append(suites.TestSuite, TestSuite{Name: pkg, TestCase: dropParentCases(cases)})
+		cases = nil
+		outputs = map[string]*strings.Builder{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case reGoTestRun.MatchString(line):
+			m := reGoTestRun.FindStringSubmatch(line)
+			current = m[1]
+			outputs[current] = &strings.Builder{}
+
+		case reGoTestEnd.MatchString(line):
+			m := reGoTestEnd.FindStringSubmatch(line)
+			status, name, elapsed := m[1], m[2], m[3]
+
+			tc := TestCase{Name: name, Time: elapsed}
+			switch status {
+			case "FAIL":
+				if out, ok := outputs[name]; ok {
+					if msg := strings.TrimSpace(out.String()); msg != "" {
+						tc.Failure = &Failure{Text: msg}
+					}
+				}
+			case "SKIP":
+				tc.Skipped = &Skip{}
+			}
+			cases = append(cases, tc)
+			current = ""
+
+		case reGoTestPkg.MatchString(line):
+			m := reGoTestPkg.FindStringSubmatch(line)
+			flush(m[2])
+
+		default:
+			if current != "" {
+				if out, ok := outputs[current]; ok {
+					out.WriteString(line)
+					out.WriteString("\n")
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return TestSuites{}, fmt.Errorf("failed to scan go test output: %w", err)
+	}
+
+	// Tests whose package never printed an "ok"/"FAIL" summary (e.g. output
+	// was truncated) still get reported under an "unknown" suite.
+	flush("unknown")
+
+	return suites, nil
+}
+
+// dropParentCases removes any case that is the parent of another recorded
+// case (e.g. "TestFoo" when "TestFoo/sub" is also present), so a test using
+// t.Run subtests is counted once via its subtests rather than double-counted
+// via both its own terminal line/event and each child's.
+func dropParentCases(cases []TestCase) []TestCase {
+	names := make(map[string]bool, len(cases))
+	for _, c := range cases {
+		names[c.Name] = true
+	}
+
+	out := make([]TestCase, 0, len(cases))
+	for _, c := range cases {
+		hasChild := false
+		for name := range names {
+			if strings.HasPrefix(name, c.Name+"/") {
+				hasChild = true
+				break
+			}
+		}
+		if !hasChild {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// goTestEvent is a single record of the `go test -json` stream.
+type goTestEvent struct {
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// parseGoTestJSON converts a `go test -json` event stream into TestSuites,
+// capturing output emitted between a test's run and terminal actions as its
+// failure message.
+func parseGoTestJSON(r io.Reader) (TestSuites, error) {
+	type pending struct {
+		pkg, test string
+		output    strings.Builder
+	}
+
+	order := []string{}
+	byKey := map[string]*pending{}
+	statuses := map[string]string{}
+	times := map[string]float64{}
+
+	dec := json.NewDecoder(r)
+	for {
+		var ev goTestEvent
+		if err := dec.Decode(&ev); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return TestSuites{}, fmt.Errorf("failed to decode go test json: %w", err)
+		}
+
+		if ev.Test == "" {
+			// Package-level event (build output, package pass/fail); not a case.
+			continue
+		}
+
+		key := ev.Package + "/" + ev.Test
+		p, ok := byKey[key]
+		if !ok {
+			p = &pending{pkg: ev.Package, test: ev.Test}
+			byKey[key] = p
+			order = append(order, key)
+		}
+
+		switch ev.Action {
+		case "output":
+			p.output.WriteString(ev.Output)
+		case "pass", "fail", "skip":
+			statuses[key] = ev.Action
+			times[key] = ev.Elapsed
+		}
+	}
+
+	// Index test names per package so a parent test's own terminal action can
+	// be ignored when it has recorded subtests.
+	testNames := map[string]map[string]bool{}
+	for _, key := range order {
+		p := byKey[key]
+		if testNames[p.pkg] == nil {
+			testNames[p.pkg] = map[string]bool{}
+		}
+		testNames[p.pkg][p.test] = true
+	}
+
+	suiteOrder := []string{}
+	suiteCases := map[string][]TestCase{}
+	for _, key := range order {
+		p := byKey[key]
+		status, ok := statuses[key]
+		if !ok {
+			// Terminal action never arrived (stream truncated); skip.
+			continue
+		}
+
+		hasChild := false
+		for name := range testNames[p.pkg] {
+			if strings.HasPrefix(name, p.test+"/") {
+				hasChild = true
+				break
+			}
+		}
+		if hasChild {
+			continue
+		}
+
+		tc := TestCase{Name: p.test, Time: fmt.Sprintf("%.2f", times[key])}
+		switch status {
+		case "fail":
+			if msg := strings.TrimSpace(p.output.String()); msg != "" {
+				tc.Failure = &Failure{Text: msg}
+			}
+		case "skip":
+			tc.Skipped = &Skip{}
+		}
+
+		if _, ok := suiteCases[p.pkg]; !ok {
+			suiteOrder = append(suiteOrder, p.pkg)
+		}
+		suiteCases[p.pkg] = append(suiteCases[p.pkg], tc)
+	}
+
+	var suites TestSuites
+	for _, pkg := range suiteOrder {
+		suites.TestSuite = append(suites.TestSuite, TestSuite{Name: pkg, TestCase: suiteCases[pkg]})
+	}
+	return suites, nil
+}
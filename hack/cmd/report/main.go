@@ -20,13 +20,13 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"sort"
 	"strings"
 )
 
 const (
-	Failed = ":x: Failed"
-	Passed = ":white_check_mark: Passed"
+	Failed  = ":x: Failed"
+	Passed  = ":white_check_mark: Passed"
+	Skipped = ":fast_forward: Skipped"
 )
 
 // Table structure to represent headers and rows
@@ -53,69 +53,76 @@ func (t *Table) Add(row []string) {
 	t.Rows = append(t.Rows, row)
 }
 
+// CaseStatus is the normalized outcome of a test case, shared by every Formatter.
+type CaseStatus string
+
+const (
+	StatusPassed  CaseStatus = "passed"
+	StatusFailed  CaseStatus = "failed"
+	StatusSkipped CaseStatus = "skipped"
+)
+
+// ReportCase is a single test case normalized out of the source XML, independent
+// of any output format.
+type ReportCase struct {
+	Suite      string
+	Case       string
+	Time       string
+	Status     CaseStatus
+	Failure    *string
+	SystemOut  string
+	SystemErr  string
+	Properties []Property
+}
+
 // Report structure to represent the test report
 type Report struct {
 	Time      string
 	Timestamp string
+	Cases     []ReportCase
 	Table     *Table
 	OK        bool
 	Failures  int
 	Passes    int
+	Skips     int
 }
 
-// NewReport creates a new report
-func NewReport(time, timestamp string, table *Table) *Report {
+// NewReport creates a new report from the normalized cases, building the
+// legacy Table representation alongside it for the MarkdownFormatter.
+func NewReport(time, timestamp string, cases []ReportCase) *Report {
 	failures := 0
 	passes := 0
+	skips := 0
 
-	for _, row := range table.Rows {
-		if contains(row, Failed) {
+	table := NewTable([]string{"Test Suite", "Test Case", "Time (s)", "Status"})
+	for _, c := range cases {
+		var status string
+		switch c.Status {
+		case StatusFailed:
 			failures++
-		} else if contains(row, Passed) {
+			status = Failed
+		case StatusSkipped:
+			skips++
+			status = Skipped
+		default:
 			passes++
+			status = Passed
 		}
+		table.Add([]string{c.Suite, c.Case, fmt.Sprintf("`%s`", c.Time), status})
 	}
 
 	return &Report{
 		Time:      time,
 		Timestamp: timestamp,
+		Cases:     cases,
 		Table:     table,
 		OK:        failures == 0,
 		Failures:  failures,
 		Passes:    passes,
+		Skips:     skips,
 	}
 }
 
-// Print outputs the report to the provided writer as markdown
-func (r *Report) Print(w io.Writer) {
-	fmt.Fprintf(w, "## E2E report %s\n", ifElse(r.OK, Passed, Failed))
-	fmt.Fprintf(w, "Started at `%s` took `%s`\n\n", r.Timestamp, r.Time)
-	fmt.Fprintf(w, "![](https://img.shields.io/badge/tests-%d_passed%%2C_%d_failed-%s)\n\n",
-		r.Passes, r.Failures, ifElse(r.OK, "green", "red"))
-
-	// Print table headers and separator
-	fmt.Fprintln(w, strings.Join(r.Table.Headers, "|"))
-	fmt.Fprintln(w, strings.Join(r.Table.Separator, "|"))
-
-	// Sort and print table rows by status
-	sort.Slice(r.Table.Rows, func(i, j int) bool {
-		return r.Table.Rows[i][len(r.Table.Rows[i])-1] > r.Table.Rows[j][len(r.Table.Rows[j])-1]
-	})
-	for _, row := range r.Table.Rows {
-		fmt.Fprintln(w, strings.Join(row, "|"))
-	}
-}
-
-// contains checks if a slice contains a string
-func contains(slice []string, value string) bool {
-	for _, v := range slice {
-		if v == value {
-			return true
-		}
-	}
-	return false
-}
-
 // ifElse returns trueVal if condition is true, else falseVal
 func ifElse(condition bool, trueVal, falseVal string) string {
 	if condition {
@@ -133,9 +140,30 @@ type TestSuite struct {
 
 // TestCase represents the XML structure for a test case
 type TestCase struct {
-	Name    string  `xml:"name,attr"`
-	Time    string  `xml:"time,attr"`
-	Failure *string `xml:"failure"`
+	Name       string     `xml:"name,attr"`
+	Time       string     `xml:"time,attr"`
+	Failure    *Failure   `xml:"failure"`
+	Skipped    *Skip      `xml:"skipped"`
+	SystemOut  string     `xml:"system-out,omitempty"`
+	SystemErr  string     `xml:"system-err,omitempty"`
+	Properties []Property `xml:"properties>property,omitempty"`
+}
+
+// Failure represents a failed test case's <failure> element.
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Skip represents a skipped test case's <skipped> element.
+type Skip struct {
+	Message string `xml:"message,attr"`
+}
+
+// Property represents a single <properties><property> entry.
+type Property struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
 }
 
 // TestSuites represents the XML structure for the root testsuites element
@@ -146,48 +174,137 @@ type TestSuites struct {
 	Timestamp string      `xml:"timestamp,attr"`
 }
 
-// generateMarkdown reads the XML report, generates and prints the markdown report
-func generateMarkdown(reportPath string, writer io.Writer) error {
-	xmlFile, err := os.Open(reportPath)
+// parseReport reads reportPath and converts it to a TestSuites according to
+// inputFormat: "chainsaw-xml" (the default) unmarshals Chainsaw's JUnit-style
+// XML directly; "gotest-text" and "gotest-json" reconstruct the same
+// structure from `go test -v` / `go test -json` output.
+func parseReport(reportPath, inputFormat string) (TestSuites, error) {
+	file, err := os.Open(reportPath)
 	if err != nil {
-		return fmt.Errorf("failed to open report file: %w", err)
+		return TestSuites{}, fmt.Errorf("failed to open report file: %w", err)
 	}
-	defer xmlFile.Close()
+	defer file.Close()
 
-	xmlData, err := io.ReadAll(xmlFile)
-	if err != nil {
-		return fmt.Errorf("failed to read report file: %w", err)
-	}
+	switch inputFormat {
+	case "", "chainsaw-xml":
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return TestSuites{}, fmt.Errorf("failed to read report file: %w", err)
+		}
+		var testSuites TestSuites
+		if err := xml.Unmarshal(data, &testSuites); err != nil {
+			return TestSuites{}, fmt.Errorf("failed to unmarshal XML: %w", err)
+		}
+		return testSuites, nil
 
-	var testSuites TestSuites
-	if err := xml.Unmarshal(xmlData, &testSuites); err != nil {
-		return fmt.Errorf("failed to unmarshal XML: %w", err)
-	}
+	case "gotest-text":
+		return parseGoTestText(file)
 
-	table := NewTable([]string{"Test Suite", "Test Case", "Time (s)", "Status"})
+	case "gotest-json":
+		return parseGoTestJSON(file)
+
+	default:
+		return TestSuites{}, fmt.Errorf("unknown input format %q (want chainsaw-xml, gotest-text, gotest-json)", inputFormat)
+	}
+}
 
+// casesFromSuites flattens the XML model into the normalized ReportCase slice
+// every Formatter works from.
+func casesFromSuites(testSuites TestSuites) []ReportCase {
+	var cases []ReportCase
 	for _, suite := range testSuites.TestSuite {
 		for _, testcase := range suite.TestCase {
-			status := Passed
-			if testcase.Failure != nil {
-				status = Failed
+			status := StatusPassed
+			var failure *string
+			switch {
+			case testcase.Failure != nil:
+				status = StatusFailed
+				msg := testcase.Failure.Message
+				if text := strings.TrimSpace(testcase.Failure.Text); text != "" {
+					if msg != "" {
+						msg += "\n"
+					}
+					msg += text
+				}
+				failure = &msg
+			case testcase.Skipped != nil:
+				status = StatusSkipped
 			}
-			table.Add([]string{suite.Name, testcase.Name, fmt.Sprintf("`%s`", testcase.Time), status})
+
+			cases = append(cases, ReportCase{
+				Suite:      suite.Name,
+				Case:       testcase.Name,
+				Time:       testcase.Time,
+				Status:     status,
+				Failure:    failure,
+				SystemOut:  testcase.SystemOut,
+				SystemErr:  testcase.SystemErr,
+				Properties: testcase.Properties,
+			})
 		}
 	}
+	return cases
+}
+
+// generateReport reads the XML report and renders it with formatter. When
+// historyDir is non-empty and formatter is the MarkdownFormatter, a "Trend"
+// section covering up to historyLimit prior runs (plus the current one) is
+// appended below the main table.
+func generateReport(reportPath, inputFormat, historyDir string, historyLimit int, formatter Formatter, gatePolicy *GatePolicy, writer io.Writer) ([]string, error) {
+	testSuites, err := parseReport(reportPath, inputFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	report := NewReport(testSuites.Time, testSuites.Timestamp, casesFromSuites(testSuites))
+	if err := formatter.Format(report, writer); err != nil {
+		return nil, fmt.Errorf("failed to format report: %w", err)
+	}
+
+	_, isMarkdown := formatter.(MarkdownFormatter)
+
+	if historyDir != "" && isMarkdown {
+		if err := printTrend(writer, testSuites, historyDir, historyLimit); err != nil {
+			return nil, err
+		}
+	}
+
+	if gatePolicy == nil {
+		return nil, nil
+	}
 
-	report := NewReport(testSuites.Time, testSuites.Timestamp, table)
-	report.Print(writer)
+	result, err := evaluateGate(report, *gatePolicy)
+	if err != nil {
+		return nil, err
+	}
+	if isMarkdown && gatePolicy.Baseline != "" {
+		printGateSections(writer, result)
+	}
 
-	return nil
+	return result.Violations, nil
 }
 
 // main function to run the report generator
 func main() {
 	reportPath := flag.String("file", "chainsaw-report.xml", "Path to XML report generated by Chainsaw")
 	outputPath := flag.String("output", "", "Output file (defaults to stdout)")
+	historyDir := flag.String("history-dir", "", "Directory of prior XML reports to render a Trend/Flaky tests section from")
+	historyLimit := flag.Int("history-limit", 10, "Maximum number of runs (including the current one) to show in the Trend section")
+	format := flag.String("format", "markdown", "Output format: markdown, json, csv, html, junit")
+	inputFormat := flag.String("input-format", "chainsaw-xml", "Input format: chainsaw-xml, gotest-text, gotest-json")
+	gate := flag.Bool("gate", false, "Enable gate mode: exit non-zero when the configured policies are violated")
+	failOnAny := flag.Bool("fail-on-any", false, "Gate policy: fail if any test failed")
+	maxFailures := flag.Int("max-failures", -1, "Gate policy: fail if failures exceed N (-1 disables)")
+	maxDuration := flag.Duration("max-duration", 0, "Gate policy: fail if the run took longer than this duration (0 disables)")
+	baseline := flag.String("baseline", "", "Gate policy: path to a prior chainsaw-xml report; fail on regressions vs this baseline")
 	flag.Parse()
 
+	formatter, err := formatterFor(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
 	var writer io.Writer = os.Stdout
 	if *outputPath != "" {
 		file, err := os.Create(*outputPath)
@@ -199,9 +316,28 @@ func main() {
 		writer = file
 	}
 
-	if err := generateMarkdown(*reportPath, writer); err != nil {
+	var gatePolicy *GatePolicy
+	if *gate {
+		gatePolicy = &GatePolicy{
+			FailOnAny:   *failOnAny,
+			MaxFailures: *maxFailures,
+			MaxDuration: *maxDuration,
+			Baseline:    *baseline,
+		}
+	}
+
+	violations, err := generateReport(*reportPath, *inputFormat, *historyDir, *historyLimit, formatter, gatePolicy, writer)
+	if err != nil {
 		fmt.Fprintf(writer, "## Report generation failed :skull:\n\n")
 		fmt.Fprintf(writer, "```log\n%v\n```\n", err)
 		os.Exit(1)
 	}
+
+	if len(violations) > 0 {
+		fmt.Fprintln(os.Stderr, "gate: failing due to:")
+		for _, v := range violations {
+			fmt.Fprintf(os.Stderr, "  - %s\n", v)
+		}
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,184 @@
+// Copyright 2024 Registry Operator contributors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	trendPassed  = ":white_check_mark:"
+	trendFailed  = ":x:"
+	trendSkipped = ":fast_forward:"
+	trendAbsent  = ":heavy_minus_sign:"
+)
+
+// historyRun holds a single parsed report together with the timestamp it was
+// recorded at, so a directory of reports can be ordered chronologically.
+type historyRun struct {
+	timestamp string
+	suites    TestSuites
+}
+
+// loadHistory reads every *.xml file in dir and returns the parsed runs
+// sorted chronologically by their <testsuites timestamp="..."> attribute,
+// oldest first. Files that fail to parse are skipped.
+func loadHistory(dir string) ([]historyRun, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history dir: %w", err)
+	}
+
+	var runs []historyRun
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".xml") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		var suites TestSuites
+		if err := xml.Unmarshal(data, &suites); err != nil {
+			continue
+		}
+
+		runs = append(runs, historyRun{timestamp: suites.Timestamp, suites: suites})
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].timestamp < runs[j].timestamp
+	})
+
+	return runs, nil
+}
+
+// trendKey identifies a test case across runs.
+func trendKey(suiteName, caseName string) string {
+	return suiteName + "/" + caseName
+}
+
+// buildTrendStatuses walks the chronological runs and records, for every
+// test case seen, its status in each run (or trendAbsent when the case
+// didn't run that time).
+func buildTrendStatuses(runs []historyRun) (keys []string, statuses map[string][]string) {
+	statuses = map[string][]string{}
+
+	for i, run := range runs {
+		seen := map[string]bool{}
+
+		for _, suite := range run.suites.TestSuite {
+			for _, tc := range suite.TestCase {
+				key := trendKey(suite.Name, tc.Name)
+				if _, ok := statuses[key]; !ok {
+					keys = append(keys, key)
+					statuses[key] = make([]string, len(runs))
+					for j := range statuses[key] {
+						statuses[key][j] = trendAbsent
+					}
+				}
+
+				// A run may legitimately report the same case name more than
+				// once (e.g. a retried test); keep the first result instead
+				// of letting a later one silently overwrite it.
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				status := trendPassed
+				switch {
+				case tc.Failure != nil:
+					status = trendFailed
+				case tc.Skipped != nil:
+					status = trendSkipped
+				}
+				statuses[key][i] = status
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	return keys, statuses
+}
+
+// isFlaky reports whether a trend row took on more than one distinct status
+// (ignoring runs where the case was absent) across the recorded runs, e.g.
+// pass/fail flapping or a case that's sometimes skipped and sometimes not.
+func isFlaky(runStatuses []string) bool {
+	seen := map[string]bool{}
+	for _, status := range runStatuses {
+		if status == trendAbsent {
+			continue
+		}
+		seen[status] = true
+	}
+	return len(seen) > 1
+}
+
+// printTrend renders the "Trend" and "Flaky tests" sections covering the
+// current run plus up to limit prior runs loaded from historyDir.
+func printTrend(w io.Writer, current TestSuites, historyDir string, limit int) error {
+	runs, err := loadHistory(historyDir)
+	if err != nil {
+		return err
+	}
+
+	runs = append(runs, historyRun{timestamp: current.Timestamp, suites: current})
+	if len(runs) > limit {
+		runs = runs[len(runs)-limit:]
+	}
+
+	keys, statuses := buildTrendStatuses(runs)
+
+	fmt.Fprintf(w, "\n## Trend (last %d runs)\n\n", len(runs))
+
+	headers := []string{"Test"}
+	separator := []string{"---"}
+	for _, run := range runs {
+		headers = append(headers, fmt.Sprintf("`%s`", run.timestamp))
+		separator = append(separator, "---")
+	}
+	fmt.Fprintln(w, strings.Join(headers, "|"))
+	fmt.Fprintln(w, strings.Join(separator, "|"))
+
+	var flaky []string
+	for _, key := range keys {
+		row := append([]string{key}, statuses[key]...)
+		fmt.Fprintln(w, strings.Join(row, "|"))
+		if isFlaky(statuses[key]) {
+			flaky = append(flaky, key)
+		}
+	}
+
+	fmt.Fprintf(w, "\n### Flaky tests\n\n")
+	if len(flaky) == 0 {
+		fmt.Fprintln(w, "None :tada:")
+		return nil
+	}
+	for _, key := range flaky {
+		fmt.Fprintf(w, "- `%s`\n", key)
+	}
+
+	return nil
+}